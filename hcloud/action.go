@@ -0,0 +1,113 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+// Action represents an action in the Hetzner Cloud.
+type Action struct {
+	ID           int
+	Command      string
+	Status       string
+	Progress     int
+	Error        *Error
+	ResourceIDs  []int
+	ResourceType string
+}
+
+// ActionFromSchema converts a schema.Action to an Action.
+func ActionFromSchema(s schema.Action) Action {
+	action := Action{
+		ID:           s.ID,
+		Command:      s.Command,
+		Status:       s.Status,
+		Progress:     s.Progress,
+		ResourceIDs:  s.ResourceIDs,
+		ResourceType: s.ResourceType,
+	}
+	if s.Error != nil {
+		err := ErrorFromSchema(*s.Error)
+		action.Error = &err
+	}
+	return action
+}
+
+// ActionClient is a client for the actions API.
+type ActionClient struct {
+	client *Client
+}
+
+// Get retrieves an action.
+func (c *ActionClient) Get(ctx context.Context, id int) (*Action, *Response, error) {
+	var body schema.ActionGetResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", fmt.Sprintf("/actions/%d", id), nil, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// ActionListOpts specifies options for listing actions.
+type ActionListOpts struct {
+	ListOpts
+}
+
+// List returns a list of actions for a specific page.
+func (c *ActionClient) List(ctx context.Context, opts ActionListOpts) ([]*Action, *Response, error) {
+	path := "/actions?" + valuesForListOpts(opts.ListOpts).Encode()
+	var body schema.ActionListResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", path, nil, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	actions := make([]*Action, 0, len(body.Actions))
+	for _, s := range body.Actions {
+		action := ActionFromSchema(s)
+		actions = append(actions, &action)
+	}
+	return actions, resp, nil
+}
+
+// EachPage calls fn for each page of actions matching opts, stopping when fn
+// returns false, returns an error, or there are no more pages.
+func (c *ActionClient) EachPage(ctx context.Context, opts ActionListOpts, fn func([]*Action) (bool, error)) error {
+	_, err := c.client.EachPage(ctx, "/actions", opts.ListOpts, func(page Page) (bool, error) {
+		var body schema.ActionListResponse
+		if err := json.Unmarshal(page.Body, &body); err != nil {
+			return false, err
+		}
+		actions := make([]*Action, 0, len(body.Actions))
+		for _, s := range body.Actions {
+			action := ActionFromSchema(s)
+			actions = append(actions, &action)
+		}
+		return fn(actions)
+	})
+	return err
+}
+
+// All returns all actions.
+func (c *ActionClient) All(ctx context.Context) ([]*Action, error) {
+	allActions := []*Action{}
+
+	opts := ActionListOpts{}
+	opts.PerPage = 50
+
+	err := c.EachPage(ctx, opts, func(actions []*Action) (bool, error) {
+		allActions = append(allActions, actions...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allActions, nil
+}
@@ -0,0 +1,198 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+// Endpoint is the base URL of the Hetzner Cloud API.
+const Endpoint = "https://api.hetzner.cloud/v1"
+
+// UserAgent is the value sent in the User-Agent header unless overridden via
+// WithApplication.
+const UserAgent = "hcloud-go"
+
+// Client is a client for the Hetzner Cloud API.
+type Client struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+	userAgent  string
+
+	mu            sync.Mutex
+	readDeadline  time.Duration
+	writeDeadline time.Duration
+	retryPolicy   RetryPolicy
+
+	Action ActionClient
+	Image  ImageClient
+	Server ServerClient
+	SSHKey SSHKeyClient
+}
+
+// ClientOption configures a Client.
+type ClientOption func(client *Client)
+
+// WithEndpoint configures a Client to use the given API endpoint.
+func WithEndpoint(endpoint string) ClientOption {
+	return func(client *Client) {
+		client.endpoint = strings.TrimRight(endpoint, "/")
+	}
+}
+
+// WithToken configures a Client to authenticate requests with the given
+// token.
+func WithToken(token string) ClientOption {
+	return func(client *Client) {
+		client.token = token
+	}
+}
+
+// WithHTTPClient configures a Client to make HTTP requests through the
+// given *http.Client.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(client *Client) {
+		client.httpClient = httpClient
+	}
+}
+
+// WithApplication configures a Client to identify itself with the given
+// application name and version in the User-Agent header.
+func WithApplication(name, version string) ClientOption {
+	return func(client *Client) {
+		client.userAgent = strings.TrimSpace(fmt.Sprintf("%s/%s %s", name, version, UserAgent))
+	}
+}
+
+// NewClient creates a new Client with the given options applied.
+func NewClient(options ...ClientOption) *Client {
+	client := &Client{
+		endpoint:   Endpoint,
+		httpClient: &http.Client{},
+		userAgent:  UserAgent,
+	}
+
+	client.Action = ActionClient{client: client}
+	client.Image = ImageClient{client: client}
+	client.Server = ServerClient{client: client}
+	client.SSHKey = SSHKeyClient{client: client}
+
+	for _, option := range options {
+		option(client)
+	}
+
+	return client
+}
+
+// NewRequest creates an *http.Request for the given method and path, with
+// body as its request body if not nil, ready to be sent via Do.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequest(method, c.endpoint+path, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return req, nil
+}
+
+// Do sends req and decodes the response body into v, which may be nil if the
+// caller does not care about the response body. It returns a Response
+// wrapping the *http.Response together with any pagination metadata found
+// in the body; rate-limit headers are not parsed.
+func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
+	httpResp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	body, err := ioutil.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &Response{Response: httpResp}
+	if err := resp.readMeta(body); err != nil {
+		return resp, fmt.Errorf("hcloud: failed to read response meta: %v", err)
+	}
+
+	if httpResp.StatusCode >= 400 {
+		var errBody schema.ErrorResponse
+		if err := json.Unmarshal(body, &errBody); err != nil {
+			return resp, fmt.Errorf("hcloud: server responded with status %d", httpResp.StatusCode)
+		}
+		return resp, ErrorFromSchema(errBody.Error)
+	}
+
+	if v != nil && len(body) > 0 {
+		if w, ok := v.(io.Writer); ok {
+			if _, err := w.Write(body); err != nil {
+				return resp, err
+			}
+		} else if err := json.Unmarshal(body, v); err != nil {
+			return resp, err
+		}
+	}
+
+	return resp, nil
+}
+
+// Response wraps an *http.Response together with pagination metadata
+// parsed out of its body.
+type Response struct {
+	*http.Response
+	Meta Meta
+}
+
+func (r *Response) readMeta(body []byte) error {
+	if !strings.Contains(r.Header.Get("Content-Type"), "application/json") {
+		return nil
+	}
+	var s struct {
+		Meta *schema.Meta `json:"meta"`
+	}
+	if err := json.Unmarshal(body, &s); err != nil {
+		return err
+	}
+	if s.Meta != nil {
+		r.Meta = MetaFromSchema(*s.Meta)
+	}
+	return nil
+}
+
+// ListOpts specifies options common to all List calls.
+type ListOpts struct {
+	Page    int
+	PerPage int
+}
+
+func valuesForListOpts(opts ListOpts) url.Values {
+	vals := url.Values{}
+	if opts.Page > 0 {
+		vals.Add("page", strconv.Itoa(opts.Page))
+	}
+	if opts.PerPage > 0 {
+		vals.Add("per_page", strconv.Itoa(opts.PerPage))
+	}
+	return vals
+}
@@ -0,0 +1,234 @@
+package hcloud
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// maxBackoffShift caps the exponent used when computing an exponential
+// backoff delay. At the default Base of 500ms, Base*2^35 already exceeds
+// time.Duration's range and would overflow into a negative duration;
+// capping the shift bounds the exponent for any attempt count, however
+// large, and ShouldRetry saturates Base<<shift for a Base too large to be
+// shifted even this far.
+const maxBackoffShift = 32
+
+// SetReadDeadline sets the maximum duration of each request attempt,
+// independent of any deadline carried by the caller's context.Context. The
+// timer starts when the attempt starts, not once the request has been sent,
+// and when it fires the whole attempt is canceled, whether it is still
+// sending the request or reading the response. It applies to every request
+// made afterwards, each timed independently of the others. A duration <= 0
+// disables the read deadline.
+func (c *Client) SetReadDeadline(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = d
+}
+
+// SetWriteDeadline sets the maximum duration of each request attempt,
+// independent of any deadline carried by the caller's context.Context. The
+// timer starts when the attempt starts and is not stopped once the request
+// has been sent, so like the read deadline it bounds the whole attempt,
+// including reading the response. It applies to every request made
+// afterwards, each timed independently of the others. A duration <= 0
+// disables the write deadline.
+func (c *Client) SetWriteDeadline(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeDeadline = d
+}
+
+func (c *Client) deadlines() (read, write time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.readDeadline, c.writeDeadline
+}
+
+// WithReadDeadline configures a Client with an initial read deadline; see
+// Client.SetReadDeadline.
+func WithReadDeadline(d time.Duration) ClientOption {
+	return func(client *Client) {
+		client.readDeadline = d
+	}
+}
+
+// WithWriteDeadline configures a Client with an initial write deadline; see
+// Client.SetWriteDeadline.
+func WithWriteDeadline(d time.Duration) ClientOption {
+	return func(client *Client) {
+		client.writeDeadline = d
+	}
+}
+
+// RetryPolicy decides whether the request that produced resp (which is nil
+// on a network error) or err should be retried, and if so after how long.
+// attempt is the zero-based index of the attempt that produced resp or err,
+// that is, the number of attempts made before it.
+type RetryPolicy interface {
+	ShouldRetry(attempt int, resp *http.Response, err error) (retry bool, delay time.Duration)
+}
+
+// ExponentialBackoff is a RetryPolicy that retries 429 and 5xx responses as
+// well as network errors. It honors a Retry-After header when the server
+// sends one, and otherwise backs off exponentially with full jitter.
+type ExponentialBackoff struct {
+	// Base is the initial backoff delay. Defaults to 500ms.
+	Base time.Duration
+	// MaxDelay caps the computed backoff delay. A delay taken from a
+	// Retry-After header is not capped. Zero means uncapped.
+	MaxDelay time.Duration
+	// MaxAttempts is the maximum number of attempts, including the first.
+	// Zero means retry indefinitely.
+	MaxAttempts int
+}
+
+// ShouldRetry implements RetryPolicy.
+func (b ExponentialBackoff) ShouldRetry(attempt int, resp *http.Response, err error) (bool, time.Duration) {
+	if b.MaxAttempts > 0 && attempt+1 >= b.MaxAttempts {
+		return false, 0
+	}
+	// Client.Do returns a non-nil err for every status >= 400, so only a nil
+	// resp marks a network error.
+	if resp != nil &&
+		resp.StatusCode != http.StatusTooManyRequests &&
+		resp.StatusCode < http.StatusInternalServerError {
+		return false, 0
+	}
+
+	if resp != nil {
+		if ra := resp.Header.Get("Retry-After"); ra != "" {
+			if secs, parseErr := strconv.Atoi(ra); parseErr == nil {
+				// Retry-After is the server dictating exactly how long to
+				// wait; honor it as-is rather than clamping it to MaxDelay,
+				// which exists to bound our own computed backoff.
+				return true, time.Duration(secs) * time.Second
+			}
+		}
+	}
+
+	base := b.Base
+	if base <= 0 {
+		base = 500 * time.Millisecond
+	}
+	shift := attempt
+	if shift > maxBackoffShift {
+		shift = maxBackoffShift
+	}
+	// Saturate rather than overflow when base is too large for base<<shift
+	// to fit in an int64.
+	delay := time.Duration(math.MaxInt64 - 1)
+	if base <= time.Duration(math.MaxInt64-1)>>uint(shift) {
+		delay = base << uint(shift)
+	}
+	delay = b.capDelay(delay)
+
+	// Full jitter: pick uniformly in [0, delay] so that retrying clients
+	// spread out instead of thundering in lockstep. delay is always > 0
+	// here: base<<shift is saturated below math.MaxInt64 instead of
+	// overflowing, and capDelay only lowers a value that is already positive.
+	return true, time.Duration(rand.Int63n(int64(delay) + 1))
+}
+
+func (b ExponentialBackoff) capDelay(d time.Duration) time.Duration {
+	if b.MaxDelay > 0 && d > b.MaxDelay {
+		return b.MaxDelay
+	}
+	return d
+}
+
+// WithRetryPolicy configures a Client to retry failed requests according to
+// policy. Without this option, a Client does not retry failed requests.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(client *Client) {
+		client.retryPolicy = policy
+	}
+}
+
+// doWithRetry builds a request for method/path/body, sends it through Do,
+// and applies the Client's configured read/write deadlines and RetryPolicy
+// around each attempt. Every client method that performs a request (Get,
+// List, Create, Delete, EachPage, ...) calls this instead of calling
+// NewRequest/Do directly, so the deadlines and retry policy configured on a
+// Client apply uniformly.
+//
+// Each attempt gets its own deadline timers, created fresh inside
+// withDeadlines rather than shared on the Client: two requests in flight at
+// once on the same Client must not be able to rearm or cancel each other's
+// deadline.
+func (c *Client) doWithRetry(ctx context.Context, method, path string, body []byte, v interface{}) (*Response, error) {
+	for attempt := 0; ; attempt++ {
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+		req, err := c.NewRequest(ctx, method, path, bodyReader)
+		if err != nil {
+			return nil, err
+		}
+
+		reqCtx, cancel := c.withDeadlines(ctx)
+		resp, err := c.Do(req.WithContext(reqCtx), v)
+		cancel()
+
+		if c.retryPolicy == nil {
+			return resp, err
+		}
+
+		var httpResp *http.Response
+		if resp != nil {
+			httpResp = resp.Response
+		}
+		retry, delay := c.retryPolicy.ShouldRetry(attempt, httpResp, err)
+		if !retry {
+			return resp, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// withDeadlines returns a context that is canceled when ctx is done, or
+// when the Client's configured read or write deadline elapses, whichever
+// comes first. The timers it starts belong only to this call: they are
+// local variables, not state shared with any other in-flight request on the
+// same Client, so concurrent requests cannot cancel or extend each other's
+// deadline.
+func (c *Client) withDeadlines(ctx context.Context) (context.Context, context.CancelFunc) {
+	readDeadline, writeDeadline := c.deadlines()
+	if readDeadline <= 0 && writeDeadline <= 0 {
+		return context.WithCancel(ctx)
+	}
+
+	dctx, cancel := context.WithCancel(ctx)
+
+	var readTimer, writeTimer *time.Timer
+	if readDeadline > 0 {
+		readTimer = time.AfterFunc(readDeadline, cancel)
+	}
+	if writeDeadline > 0 {
+		writeTimer = time.AfterFunc(writeDeadline, cancel)
+	}
+
+	return dctx, func() {
+		cancel()
+		if readTimer != nil {
+			readTimer.Stop()
+		}
+		if writeTimer != nil {
+			writeTimer.Stop()
+		}
+	}
+}
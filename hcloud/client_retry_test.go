@@ -0,0 +1,182 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+func TestExponentialBackoffShouldRetry(t *testing.T) {
+	b := ExponentialBackoff{Base: time.Millisecond, MaxDelay: 10 * time.Millisecond}
+
+	retry, _ := b.ShouldRetry(0, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+	if !retry {
+		t.Error("expected a 429 response to be retried")
+	}
+
+	retry, _ = b.ShouldRetry(0, &http.Response{StatusCode: http.StatusBadGateway}, nil)
+	if !retry {
+		t.Error("expected a 502 response to be retried")
+	}
+
+	retry, _ = b.ShouldRetry(0, &http.Response{StatusCode: http.StatusNotFound}, nil)
+	if retry {
+		t.Error("expected a 404 response not to be retried")
+	}
+
+	header := http.Header{}
+	header.Set("Retry-After", "1")
+	retry, delay := b.ShouldRetry(0, &http.Response{StatusCode: http.StatusTooManyRequests, Header: header}, nil)
+	if !retry || delay != time.Second {
+		t.Errorf("expected Retry-After to be honored; got retry=%v delay=%v", retry, delay)
+	}
+}
+
+func TestExponentialBackoffMaxAttempts(t *testing.T) {
+	b := ExponentialBackoff{MaxAttempts: 2}
+
+	if retry, _ := b.ShouldRetry(0, &http.Response{StatusCode: http.StatusTooManyRequests}, nil); !retry {
+		t.Error("expected attempt 0 to be retried")
+	}
+	if retry, _ := b.ShouldRetry(1, &http.Response{StatusCode: http.StatusTooManyRequests}, nil); retry {
+		t.Error("expected retries to stop once MaxAttempts is reached")
+	}
+}
+
+func TestExponentialBackoffDoesNotOverflow(t *testing.T) {
+	b := ExponentialBackoff{Base: 500 * time.Millisecond}
+
+	// At attempt 35, Base*2^35 would overflow time.Duration (int64) into a
+	// negative number, which would make rand.Int63n panic.
+	for _, attempt := range []int{30, 35, 50, 1000} {
+		retry, delay := b.ShouldRetry(attempt, &http.Response{StatusCode: http.StatusTooManyRequests}, nil)
+		if !retry {
+			t.Fatalf("attempt %d: expected retry", attempt)
+		}
+		if delay < 0 {
+			t.Fatalf("attempt %d: delay overflowed to a negative duration: %v", attempt, delay)
+		}
+	}
+}
+
+func TestClientWithDeadlinesCancelsOnReadDeadline(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Client.SetReadDeadline(time.Millisecond)
+
+	ctx, cancel := env.Client.withDeadlines(context.Background())
+	defer cancel()
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("expected context to be canceled once the read deadline elapsed")
+	}
+}
+
+// TestClientWithDeadlinesRearmsPerRequest proves the read deadline is a
+// per-request deadline rather than a single timer that fires once and then
+// stays permanently expired: a second call made well after the first one
+// timed out must get its own fresh window before being canceled.
+func TestClientWithDeadlinesRearmsPerRequest(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Client.SetReadDeadline(15 * time.Millisecond)
+
+	ctx1, cancel1 := env.Client.withDeadlines(context.Background())
+	<-ctx1.Done()
+	cancel1()
+
+	ctx2, cancel2 := env.Client.withDeadlines(context.Background())
+	defer cancel2()
+
+	select {
+	case <-ctx2.Done():
+		t.Fatal("second request's context was canceled immediately; deadline did not rearm per request")
+	case <-time.After(5 * time.Millisecond):
+	}
+
+	select {
+	case <-ctx2.Done():
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("second request's deadline never fired")
+	}
+}
+
+// TestClientWithDeadlinesConcurrentRequestsAreIndependent proves that two
+// requests in flight at the same time on the same Client do not share
+// deadline state: a long-lived request started first must not be canceled
+// early just because a second, short-deadline request is started and
+// expires while the first is still within its own window.
+func TestClientWithDeadlinesConcurrentRequestsAreIndependent(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	env.Client.SetReadDeadline(100 * time.Millisecond)
+	longCtx, longCancel := env.Client.withDeadlines(context.Background())
+	defer longCancel()
+
+	var wg sync.WaitGroup
+	wg.Add(20)
+	for i := 0; i < 20; i++ {
+		go func() {
+			defer wg.Done()
+			env.Client.SetReadDeadline(time.Millisecond)
+			ctx, cancel := env.Client.withDeadlines(context.Background())
+			<-ctx.Done()
+			cancel()
+		}()
+	}
+	wg.Wait()
+
+	select {
+	case <-longCtx.Done():
+		t.Fatal("long-deadline request was canceled by unrelated concurrent requests' deadlines")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+// TestClientDoRetriesOn429 exercises the retry policy through a real client
+// method (SSHKeyClient.Get, which now calls Client.doWithRetry instead of
+// NewRequest/Do directly) rather than calling doWithRetry in isolation, so
+// the test fails if the feature is ever disconnected from a real call path
+// again.
+func TestClientDoRetriesOn429(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	requests := 0
+	env.Mux.HandleFunc("/ssh_keys/1", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(schema.SSHKeyGetResponse{
+			SSHKey: schema.SSHKey{ID: 1},
+		})
+	})
+
+	env.Client.retryPolicy = ExponentialBackoff{Base: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+	ctx := context.Background()
+	sshKey, _, err := env.Client.SSHKey.Get(ctx, 1)
+	if err != nil {
+		t.Fatalf("Get failed: %s", err)
+	}
+	if sshKey == nil || sshKey.ID != 1 {
+		t.Fatalf("unexpected SSH key: %+v", sshKey)
+	}
+	if requests != 3 {
+		t.Fatalf("expected 3 requests after 2 retries; got %d", requests)
+	}
+}
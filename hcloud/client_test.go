@@ -0,0 +1,37 @@
+package hcloud
+
+import (
+	"net/http"
+	"net/http/httptest"
+)
+
+// testEnv bundles a Client with the httptest.Server and ServeMux backing it,
+// so tests can register handlers for the endpoints they exercise.
+type testEnv struct {
+	Server *httptest.Server
+	Client *Client
+	Mux    *http.ServeMux
+}
+
+// Teardown closes the underlying httptest.Server.
+func (env *testEnv) Teardown() {
+	env.Server.Close()
+	env.Server = nil
+	env.Client = nil
+	env.Mux = nil
+}
+
+func newTestEnv() testEnv {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	client := NewClient(
+		WithEndpoint(server.URL),
+		WithToken("test-token"),
+	)
+
+	return testEnv{
+		Server: server,
+		Client: client,
+		Mux:    mux,
+	}
+}
@@ -0,0 +1,45 @@
+package hcloud
+
+import (
+	"fmt"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+// ErrorCode represents an error code returned by the API.
+type ErrorCode string
+
+// Error codes returned by the API. These are left as untyped string
+// constants (rather than typed ErrorCode) so they can be used interchangeably
+// as an ErrorCode and as the plain string schema.Error.Code expects, without
+// a conversion at every call site.
+const (
+	ErrorCodeNotFound          = "not_found"
+	ErrorCodeInvalidInput      = "invalid_input"
+	ErrorCodeRateLimitExceeded = "rate_limit_exceeded"
+	ErrorCodeServiceError      = "service_error"
+)
+
+// Error is an error returned by the API.
+type Error struct {
+	Code    ErrorCode
+	Message string
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("%s (%s)", e.Message, e.Code)
+}
+
+// ErrorFromSchema converts a schema.Error to an Error.
+func ErrorFromSchema(s schema.Error) Error {
+	return Error{
+		Code:    ErrorCode(s.Code),
+		Message: s.Message,
+	}
+}
+
+// IsError returns whether err is an API Error with the given ErrorCode.
+func IsError(err error, code ErrorCode) bool {
+	apiErr, ok := err.(Error)
+	return ok && apiErr.Code == code
+}
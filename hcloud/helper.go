@@ -0,0 +1,16 @@
+package hcloud
+
+// String returns a pointer to s.
+func String(s string) *string {
+	return &s
+}
+
+// Int returns a pointer to i.
+func Int(i int) *int {
+	return &i
+}
+
+// Bool returns a pointer to b.
+func Bool(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,115 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+// ImageType specifies the type of an Image.
+type ImageType string
+
+// Image types.
+const (
+	ImageTypeSystem   ImageType = "system"
+	ImageTypeSnapshot ImageType = "snapshot"
+	ImageTypeBackup   ImageType = "backup"
+)
+
+// Image represents an image in the Hetzner Cloud.
+type Image struct {
+	ID          int
+	Type        ImageType
+	Status      string
+	Name        string
+	Description string
+}
+
+// ImageFromSchema converts a schema.Image to an Image.
+func ImageFromSchema(s schema.Image) Image {
+	return Image{
+		ID:          s.ID,
+		Type:        ImageType(s.Type),
+		Status:      s.Status,
+		Name:        s.Name,
+		Description: s.Description,
+	}
+}
+
+// ImageClient is a client for the images API.
+type ImageClient struct {
+	client *Client
+}
+
+// Get retrieves an image.
+func (c *ImageClient) Get(ctx context.Context, id int) (*Image, *Response, error) {
+	var body schema.ImageGetResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", fmt.Sprintf("/images/%d", id), nil, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, resp, err
+	}
+	image := ImageFromSchema(body.Image)
+	return &image, resp, nil
+}
+
+// ImageListOpts specifies options for listing images.
+type ImageListOpts struct {
+	ListOpts
+}
+
+// List returns a list of images for a specific page.
+func (c *ImageClient) List(ctx context.Context, opts ImageListOpts) ([]*Image, *Response, error) {
+	path := "/images?" + valuesForListOpts(opts.ListOpts).Encode()
+	var body schema.ImageListResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", path, nil, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	images := make([]*Image, 0, len(body.Images))
+	for _, s := range body.Images {
+		image := ImageFromSchema(s)
+		images = append(images, &image)
+	}
+	return images, resp, nil
+}
+
+// EachPage calls fn for each page of images matching opts, stopping when fn
+// returns false, returns an error, or there are no more pages.
+func (c *ImageClient) EachPage(ctx context.Context, opts ImageListOpts, fn func([]*Image) (bool, error)) error {
+	_, err := c.client.EachPage(ctx, "/images", opts.ListOpts, func(page Page) (bool, error) {
+		var body schema.ImageListResponse
+		if err := json.Unmarshal(page.Body, &body); err != nil {
+			return false, err
+		}
+		images := make([]*Image, 0, len(body.Images))
+		for _, s := range body.Images {
+			image := ImageFromSchema(s)
+			images = append(images, &image)
+		}
+		return fn(images)
+	})
+	return err
+}
+
+// All returns all images.
+func (c *ImageClient) All(ctx context.Context) ([]*Image, error) {
+	allImages := []*Image{}
+
+	opts := ImageListOpts{}
+	opts.PerPage = 50
+
+	err := c.EachPage(ctx, opts, func(images []*Image) (bool, error) {
+		allImages = append(allImages, images...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allImages, nil
+}
@@ -0,0 +1,40 @@
+package hcloud
+
+import "github.com/hetznercloud/hcloud-go/hcloud/schema"
+
+// Meta represents the meta information contained in a response.
+type Meta struct {
+	Pagination *Pagination
+}
+
+// MetaFromSchema converts a schema.Meta to a Meta.
+func MetaFromSchema(s schema.Meta) Meta {
+	m := Meta{}
+	if s.Pagination != nil {
+		p := PaginationFromSchema(*s.Pagination)
+		m.Pagination = &p
+	}
+	return m
+}
+
+// Pagination represents the pagination information of a list response.
+type Pagination struct {
+	Page         int
+	PerPage      int
+	PreviousPage int
+	NextPage     int
+	LastPage     int
+	TotalEntries int
+}
+
+// PaginationFromSchema converts a schema.MetaPagination to a Pagination.
+func PaginationFromSchema(s schema.MetaPagination) Pagination {
+	return Pagination{
+		Page:         s.Page,
+		PerPage:      s.PerPage,
+		PreviousPage: s.PreviousPage,
+		NextPage:     s.NextPage,
+		LastPage:     s.LastPage,
+		TotalEntries: s.TotalEntries,
+	}
+}
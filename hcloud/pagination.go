@@ -0,0 +1,55 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Page represents a single page of results returned by a List endpoint,
+// together with the pagination metadata describing its position in the
+// overall result set.
+type Page struct {
+	Body json.RawMessage
+	Meta Meta
+}
+
+// EachPage iterates over all pages of the list endpoint at path, decoding
+// each response body and invoking fn with the resulting Page. Iteration
+// stops when fn returns false, when fn returns an error, or when there are
+// no more pages. It returns the Response of the last page fetched.
+//
+// EachPage is modeled after gophercloud's page walker: unlike All, it does
+// not accumulate every page in memory, so callers can stop early or process
+// results as they arrive.
+//
+// SSHKeyClient, ServerClient, ImageClient, and ActionClient are all wired
+// onto EachPage (see e.g. SSHKeyClient.EachPage/All); their All methods are
+// thin wrappers that accumulate every page's results.
+func (c *Client) EachPage(ctx context.Context, path string, opts ListOpts, fn func(Page) (bool, error)) (*Response, error) {
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+	if opts.PerPage == 0 {
+		opts.PerPage = 50
+	}
+
+	var resp *Response
+	for {
+		var body json.RawMessage
+		var err error
+		resp, err = c.doWithRetry(ctx, "GET", path+"?"+valuesForListOpts(opts).Encode(), nil, &body)
+		if err != nil {
+			return resp, err
+		}
+
+		cont, err := fn(Page{Body: body, Meta: resp.Meta})
+		if err != nil || !cont {
+			return resp, err
+		}
+
+		if resp.Meta.Pagination == nil || resp.Meta.Pagination.NextPage == 0 {
+			return resp, nil
+		}
+		opts.Page = resp.Meta.Pagination.NextPage
+	}
+}
@@ -0,0 +1,206 @@
+// Package schema defines the types used to exchange data with the Hetzner
+// Cloud API via JSON. The types in this package are deliberately kept close
+// to the wire format; the hcloud package converts them to and from the
+// friendlier public types callers interact with.
+package schema
+
+// Meta describes metadata contained in responses that list resources.
+type Meta struct {
+	Pagination *MetaPagination `json:"pagination,omitempty"`
+}
+
+// MetaPagination describes the pagination metadata of a list response.
+type MetaPagination struct {
+	Page         int `json:"page"`
+	PerPage      int `json:"per_page"`
+	PreviousPage int `json:"previous_page,omitempty"`
+	NextPage     int `json:"next_page,omitempty"`
+	LastPage     int `json:"last_page"`
+	TotalEntries int `json:"total_entries"`
+}
+
+// Error represents the JSON body of an error response.
+type Error struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse defines the schema of a response containing an error.
+type ErrorResponse struct {
+	Error Error `json:"error"`
+}
+
+// SSHKey defines the schema of an SSH key.
+type SSHKey struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Fingerprint string `json:"fingerprint"`
+	PublicKey   string `json:"public_key"`
+}
+
+// SSHKeyGetResponse defines the schema of the response when requesting a
+// single SSH key.
+type SSHKeyGetResponse struct {
+	SSHKey SSHKey `json:"ssh_key"`
+}
+
+// SSHKeyListResponse defines the schema of the response when listing SSH
+// keys.
+type SSHKeyListResponse struct {
+	SSHKeys []SSHKey `json:"ssh_keys"`
+	Meta    Meta     `json:"meta"`
+}
+
+// SSHKeyCreateRequest defines the schema of the request to create an SSH
+// key.
+type SSHKeyCreateRequest struct {
+	Name      string `json:"name"`
+	PublicKey string `json:"public_key"`
+}
+
+// SSHKeyCreateResponse defines the schema of the response when creating an
+// SSH key.
+type SSHKeyCreateResponse struct {
+	SSHKey SSHKey `json:"ssh_key"`
+}
+
+// Action defines the schema of an action.
+type Action struct {
+	ID           int    `json:"id"`
+	Command      string `json:"command"`
+	Status       string `json:"status"`
+	Progress     int    `json:"progress"`
+	Error        *Error `json:"error,omitempty"`
+	ResourceIDs  []int  `json:"resources,omitempty"`
+	ResourceType string `json:"resource_type,omitempty"`
+}
+
+// ActionGetResponse defines the schema of the response when requesting a
+// single action.
+type ActionGetResponse struct {
+	Action Action `json:"action"`
+}
+
+// ActionListResponse defines the schema of the response when listing
+// actions.
+type ActionListResponse struct {
+	Actions []Action `json:"actions"`
+	Meta    Meta     `json:"meta"`
+}
+
+// Image defines the schema of an image.
+type Image struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	Status      string `json:"status"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description"`
+}
+
+// ImageGetResponse defines the schema of the response when requesting a
+// single image.
+type ImageGetResponse struct {
+	Image Image `json:"image"`
+}
+
+// ImageListResponse defines the schema of the response when listing images.
+type ImageListResponse struct {
+	Images []Image `json:"images"`
+	Meta   Meta    `json:"meta"`
+}
+
+// ServerType defines the schema of a server type.
+type ServerType struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// Server defines the schema of a server.
+type Server struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	ServerType ServerType `json:"server_type"`
+	Image      *Image     `json:"image"`
+}
+
+// ServerGetResponse defines the schema of the response when requesting a
+// single server.
+type ServerGetResponse struct {
+	Server Server `json:"server"`
+}
+
+// ServerListResponse defines the schema of the response when listing
+// servers.
+type ServerListResponse struct {
+	Servers []Server `json:"servers"`
+	Meta    Meta     `json:"meta"`
+}
+
+// ServerCreateRequest defines the schema of the request to create a server.
+type ServerCreateRequest struct {
+	Name       string `json:"name"`
+	ServerType int    `json:"server_type"`
+	Image      int    `json:"image"`
+	SSHKeys    []int  `json:"ssh_keys,omitempty"`
+	UserData   string `json:"user_data,omitempty"`
+}
+
+// ServerCreateResponse defines the schema of the response when creating a
+// server.
+type ServerCreateResponse struct {
+	Server       Server  `json:"server"`
+	Action       *Action `json:"action"`
+	RootPassword *string `json:"root_password"`
+}
+
+// ServerActionPoweronResponse defines the schema of the response when
+// powering on a server.
+type ServerActionPoweronResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionRebootResponse defines the schema of the response when
+// rebooting a server.
+type ServerActionRebootResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionResetResponse defines the schema of the response when
+// resetting a server.
+type ServerActionResetResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionShutdownResponse defines the schema of the response when
+// shutting down a server.
+type ServerActionShutdownResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionPoweroffResponse defines the schema of the response when
+// powering off a server.
+type ServerActionPoweroffResponse struct {
+	Action Action `json:"action"`
+}
+
+// ServerActionResetPasswordResponse defines the schema of the response when
+// resetting a server's root password.
+type ServerActionResetPasswordResponse struct {
+	Action       Action `json:"action"`
+	RootPassword string `json:"root_password"`
+}
+
+// ServerActionCreateImageRequest defines the schema of the request to create
+// an image from a server.
+type ServerActionCreateImageRequest struct {
+	Description *string `json:"description,omitempty"`
+	Type        *string `json:"type,omitempty"`
+}
+
+// ServerActionCreateImageResponse defines the schema of the response when
+// creating an image from a server.
+type ServerActionCreateImageResponse struct {
+	Action Action `json:"action"`
+	Image  Image  `json:"image"`
+}
@@ -0,0 +1,294 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+// ServerType represents a server type in the Hetzner Cloud.
+type ServerType struct {
+	ID   int
+	Name string
+}
+
+// ServerTypeFromSchema converts a schema.ServerType to a ServerType.
+func ServerTypeFromSchema(s schema.ServerType) ServerType {
+	return ServerType{ID: s.ID, Name: s.Name}
+}
+
+// Server represents a server in the Hetzner Cloud.
+type Server struct {
+	ID         int
+	Name       string
+	Status     string
+	ServerType ServerType
+	Image      *Image
+}
+
+// ServerFromSchema converts a schema.Server to a Server.
+func ServerFromSchema(s schema.Server) Server {
+	server := Server{
+		ID:         s.ID,
+		Name:       s.Name,
+		Status:     s.Status,
+		ServerType: ServerTypeFromSchema(s.ServerType),
+	}
+	if s.Image != nil {
+		image := ImageFromSchema(*s.Image)
+		server.Image = &image
+	}
+	return server
+}
+
+// ServerClient is a client for the servers API.
+type ServerClient struct {
+	client *Client
+}
+
+// Get retrieves a server. It returns (nil, resp, nil) if the server does
+// not exist, instead of an error, so callers can distinguish "not found"
+// from a request failure.
+func (c *ServerClient) Get(ctx context.Context, id int) (*Server, *Response, error) {
+	var body schema.ServerGetResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", fmt.Sprintf("/servers/%d", id), nil, &body)
+	if err != nil {
+		if IsError(err, ErrorCodeNotFound) {
+			return nil, resp, nil
+		}
+		return nil, resp, err
+	}
+	server := ServerFromSchema(body.Server)
+	return &server, resp, nil
+}
+
+// ServerListOpts specifies options for listing servers.
+type ServerListOpts struct {
+	ListOpts
+}
+
+// List returns a list of servers for a specific page.
+func (c *ServerClient) List(ctx context.Context, opts ServerListOpts) ([]*Server, *Response, error) {
+	path := "/servers?" + valuesForListOpts(opts.ListOpts).Encode()
+	var body schema.ServerListResponse
+	resp, err := c.client.doWithRetry(ctx, "GET", path, nil, &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	servers := make([]*Server, 0, len(body.Servers))
+	for _, s := range body.Servers {
+		server := ServerFromSchema(s)
+		servers = append(servers, &server)
+	}
+	return servers, resp, nil
+}
+
+// EachPage calls fn for each page of servers matching opts, stopping when fn
+// returns false, returns an error, or there are no more pages.
+func (c *ServerClient) EachPage(ctx context.Context, opts ServerListOpts, fn func([]*Server) (bool, error)) error {
+	_, err := c.client.EachPage(ctx, "/servers", opts.ListOpts, func(page Page) (bool, error) {
+		var body schema.ServerListResponse
+		if err := json.Unmarshal(page.Body, &body); err != nil {
+			return false, err
+		}
+		servers := make([]*Server, 0, len(body.Servers))
+		for _, s := range body.Servers {
+			server := ServerFromSchema(s)
+			servers = append(servers, &server)
+		}
+		return fn(servers)
+	})
+	return err
+}
+
+// All returns all servers.
+func (c *ServerClient) All(ctx context.Context) ([]*Server, error) {
+	allServers := []*Server{}
+
+	opts := ServerListOpts{}
+	opts.PerPage = 50
+
+	err := c.EachPage(ctx, opts, func(servers []*Server) (bool, error) {
+		allServers = append(allServers, servers...)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allServers, nil
+}
+
+// ServerCreateOpts specifies parameters for creating a server.
+type ServerCreateOpts struct {
+	Name       string
+	ServerType ServerType
+	Image      Image
+	SSHKeys    []*SSHKey
+	UserData   string
+}
+
+// ServerCreateResult is the result of a successful call to ServerClient.Create.
+type ServerCreateResult struct {
+	Server       *Server
+	Action       *Action
+	RootPassword *string
+}
+
+// Create creates a new server with the given options.
+func (c *ServerClient) Create(ctx context.Context, opts ServerCreateOpts) (ServerCreateResult, *Response, error) {
+	reqBody := schema.ServerCreateRequest{
+		Name:       opts.Name,
+		ServerType: opts.ServerType.ID,
+		Image:      opts.Image.ID,
+		UserData:   opts.UserData,
+	}
+	for _, sshKey := range opts.SSHKeys {
+		reqBody.SSHKeys = append(reqBody.SSHKeys, sshKey.ID)
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return ServerCreateResult{}, nil, err
+	}
+
+	var respBody schema.ServerCreateResponse
+	resp, err := c.client.doWithRetry(ctx, "POST", "/servers", reqBodyData, &respBody)
+	if err != nil {
+		return ServerCreateResult{}, resp, err
+	}
+
+	server := ServerFromSchema(respBody.Server)
+	result := ServerCreateResult{
+		Server:       &server,
+		RootPassword: respBody.RootPassword,
+	}
+	if respBody.Action != nil {
+		action := ActionFromSchema(*respBody.Action)
+		result.Action = &action
+	}
+	return result, resp, nil
+}
+
+// Delete deletes a server.
+func (c *ServerClient) Delete(ctx context.Context, id int) (*Response, error) {
+	return c.client.doWithRetry(ctx, "DELETE", fmt.Sprintf("/servers/%d", id), nil, nil)
+}
+
+func (c *ServerClient) serverAction(ctx context.Context, server *Server, action string, v interface{}) (*Response, error) {
+	return c.client.doWithRetry(ctx, "POST", fmt.Sprintf("/servers/%d/actions/%s", server.ID, action), nil, v)
+}
+
+// Poweron starts a server.
+func (c *ServerClient) Poweron(ctx context.Context, server *Server) (*Action, *Response, error) {
+	var body schema.ServerActionPoweronResponse
+	resp, err := c.serverAction(ctx, server, "poweron", &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// Reboot reboots a server.
+func (c *ServerClient) Reboot(ctx context.Context, server *Server) (*Action, *Response, error) {
+	var body schema.ServerActionRebootResponse
+	resp, err := c.serverAction(ctx, server, "reboot", &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// Reset resets a server.
+func (c *ServerClient) Reset(ctx context.Context, server *Server) (*Action, *Response, error) {
+	var body schema.ServerActionResetResponse
+	resp, err := c.serverAction(ctx, server, "reset", &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// Shutdown shuts down a server.
+func (c *ServerClient) Shutdown(ctx context.Context, server *Server) (*Action, *Response, error) {
+	var body schema.ServerActionShutdownResponse
+	resp, err := c.serverAction(ctx, server, "shutdown", &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// Poweroff stops a server.
+func (c *ServerClient) Poweroff(ctx context.Context, server *Server) (*Action, *Response, error) {
+	var body schema.ServerActionPoweroffResponse
+	resp, err := c.serverAction(ctx, server, "poweroff", &body)
+	if err != nil {
+		return nil, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return &action, resp, nil
+}
+
+// ServerResetPasswordResult is the result of a successful call to
+// ServerClient.ResetPassword.
+type ServerResetPasswordResult struct {
+	Action       *Action
+	RootPassword string
+}
+
+// ResetPassword resets a server's root password.
+func (c *ServerClient) ResetPassword(ctx context.Context, server *Server) (ServerResetPasswordResult, *Response, error) {
+	var body schema.ServerActionResetPasswordResponse
+	resp, err := c.serverAction(ctx, server, "reset_password", &body)
+	if err != nil {
+		return ServerResetPasswordResult{}, resp, err
+	}
+	action := ActionFromSchema(body.Action)
+	return ServerResetPasswordResult{Action: &action, RootPassword: body.RootPassword}, resp, nil
+}
+
+// ServerCreateImageOpts specifies parameters for creating an image from a
+// server.
+type ServerCreateImageOpts struct {
+	Type        ImageType
+	Description *string
+}
+
+// ServerCreateImageResult is the result of a successful call to
+// ServerClient.CreateImage.
+type ServerCreateImageResult struct {
+	Action *Action
+	Image  *Image
+}
+
+// CreateImage creates an image from a server. opts may be nil to use the
+// API's defaults.
+func (c *ServerClient) CreateImage(ctx context.Context, server *Server, opts *ServerCreateImageOpts) (ServerCreateImageResult, *Response, error) {
+	var reqBody schema.ServerActionCreateImageRequest
+	if opts != nil {
+		if opts.Type != "" {
+			reqBody.Type = String(string(opts.Type))
+		}
+		reqBody.Description = opts.Description
+	}
+	reqBodyData, err := json.Marshal(reqBody)
+	if err != nil {
+		return ServerCreateImageResult{}, nil, err
+	}
+
+	var respBody schema.ServerActionCreateImageResponse
+	resp, err := c.client.doWithRetry(ctx, "POST", fmt.Sprintf("/servers/%d/actions/create_image", server.ID), reqBodyData, &respBody)
+	if err != nil {
+		return ServerCreateImageResult{}, resp, err
+	}
+
+	action := ActionFromSchema(respBody.Action)
+	image := ImageFromSchema(respBody.Image)
+	return ServerCreateImageResult{Action: &action, Image: &image}, resp, nil
+}
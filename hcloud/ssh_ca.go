@@ -0,0 +1,112 @@
+package hcloud
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHCAClient signs OpenSSH user and host certificates for SSH keys managed
+// through SSHKeyClient, so that servers can be bootstrapped with short-lived
+// signed certificates instead of raw authorized_keys entries.
+type SSHCAClient struct {
+	client *Client
+	signer ssh.Signer
+}
+
+// NewSSHCAClient creates a new SSHCAClient that signs certificates with the
+// given CA signer.
+func NewSSHCAClient(client *Client, signer ssh.Signer) *SSHCAClient {
+	return &SSHCAClient{client: client, signer: signer}
+}
+
+// SSHSignOpts specifies parameters for signing an SSH certificate.
+type SSHSignOpts struct {
+	KeyID           string
+	ValidPrincipals []string
+	ValidAfter      uint64
+	ValidBefore     uint64
+	CriticalOptions map[string]string
+	Extensions      map[string]string
+}
+
+// certificate builds an unsigned ssh.Certificate of the given type for the
+// given SSHKey and signs it with the CA signer.
+func (c *SSHCAClient) certificate(ctx context.Context, key *SSHKey, certType uint32, opts SSHSignOpts) (*ssh.Certificate, error) {
+	sshKey, _, err := c.client.SSHKey.Get(ctx, key.ID)
+	if err != nil {
+		return nil, err
+	}
+	if sshKey == nil {
+		return nil, fmt.Errorf("hcloud: SSH key %d not found", key.ID)
+	}
+
+	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(sshKey.PublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("hcloud: parse public key of SSH key %d: %v", key.ID, err)
+	}
+
+	cert := &ssh.Certificate{
+		Key:             pubKey,
+		CertType:        certType,
+		KeyId:           opts.KeyID,
+		ValidPrincipals: opts.ValidPrincipals,
+		ValidAfter:      opts.ValidAfter,
+		ValidBefore:     opts.ValidBefore,
+		Permissions: ssh.Permissions{
+			CriticalOptions: opts.CriticalOptions,
+			Extensions:      opts.Extensions,
+		},
+	}
+
+	if err := cert.SignCert(rand.Reader, c.signer); err != nil {
+		return nil, fmt.Errorf("hcloud: sign certificate: %v", err)
+	}
+
+	return cert, nil
+}
+
+// SignUser signs an OpenSSH user certificate for the given SSHKey.
+func (c *SSHCAClient) SignUser(ctx context.Context, key *SSHKey, opts SSHSignOpts) (*ssh.Certificate, error) {
+	return c.certificate(ctx, key, ssh.UserCert, opts)
+}
+
+// SignHost signs an OpenSSH host certificate for the given SSHKey.
+func (c *SSHCAClient) SignHost(ctx context.Context, key *SSHKey, opts SSHSignOpts) (*ssh.Certificate, error) {
+	return c.certificate(ctx, key, ssh.HostCert, opts)
+}
+
+// WithSignedSSHKey signs a user certificate for key with ca and sets
+// o.UserData to a cloud-config document whose write_files stanza drops the
+// certificate at /etc/ssh/signed-cert.pub, so a caller's own cloud-init
+// boot scripts can pick it up (e.g. to populate AuthorizedPrincipalsFile and
+// TrustedUserCAKeys) instead of provisioning the raw public key.
+//
+// It returns an error if o.UserData is already set: the cloud-config
+// document is generated from scratch, and merging a write_files entry into
+// an arbitrary caller-supplied UserData payload without parsing it risks
+// corrupting that payload, so WithSignedSSHKey refuses to guess instead.
+// Callers who need to combine this with their own UserData should call
+// SignUser directly and fold ssh.MarshalAuthorizedKey(cert) into their own
+// cloud-config document.
+func (o *ServerCreateOpts) WithSignedSSHKey(ctx context.Context, ca *SSHCAClient, key *SSHKey, opts SSHSignOpts) error {
+	if o.UserData != "" {
+		return fmt.Errorf("hcloud: ServerCreateOpts.UserData must be empty to use WithSignedSSHKey")
+	}
+
+	cert, err := ca.SignUser(ctx, key, opts)
+	if err != nil {
+		return err
+	}
+
+	certData, err := json.Marshal(string(ssh.MarshalAuthorizedKey(cert)))
+	if err != nil {
+		return err
+	}
+
+	o.UserData = fmt.Sprintf("#cloud-config\nwrite_files:\n  - path: /etc/ssh/signed-cert.pub\n    content: %s\n", certData)
+	return nil
+}
@@ -0,0 +1,142 @@
+package hcloud
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+func newTestSSHCAClient(t *testing.T, client *Client) (*SSHCAClient, ssh.PublicKey) {
+	t.Helper()
+
+	_, caKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caSigner, err := ssh.NewSignerFromKey(caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return NewSSHCAClient(client, caSigner), caSigner.PublicKey()
+}
+
+func TestSSHCAClientSignUser(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	hostPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(hostPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.Mux.HandleFunc("/ssh_keys/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.SSHKeyGetResponse{
+			SSHKey: schema.SSHKey{
+				ID:        1,
+				PublicKey: string(ssh.MarshalAuthorizedKey(sshPub)),
+			},
+		})
+	})
+
+	ca, caPub := newTestSSHCAClient(t, env.Client)
+
+	ctx := context.Background()
+	cert, err := ca.SignUser(ctx, &SSHKey{ID: 1}, SSHSignOpts{
+		KeyID:           "deploy",
+		ValidPrincipals: []string{"root"},
+	})
+	if err != nil {
+		t.Fatalf("SignUser failed: %s", err)
+	}
+	if cert.CertType != ssh.UserCert {
+		t.Errorf("unexpected cert type: %v", cert.CertType)
+	}
+	if cert.KeyId != "deploy" {
+		t.Errorf("unexpected key id: %v", cert.KeyId)
+	}
+	if string(cert.SignatureKey.Marshal()) != string(caPub.Marshal()) {
+		t.Errorf("certificate was not signed by the CA key")
+	}
+}
+
+func TestServerCreateOptsWithSignedSSHKey(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	hostPub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshPub, err := ssh.NewPublicKey(hostPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env.Mux.HandleFunc("/ssh_keys/1", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(schema.SSHKeyGetResponse{
+			SSHKey: schema.SSHKey{
+				ID:        1,
+				PublicKey: string(ssh.MarshalAuthorizedKey(sshPub)),
+			},
+		})
+	})
+
+	ca, _ := newTestSSHCAClient(t, env.Client)
+
+	opts := &ServerCreateOpts{Name: "test"}
+	ctx := context.Background()
+	if err := opts.WithSignedSSHKey(ctx, ca, &SSHKey{ID: 1}, SSHSignOpts{ValidPrincipals: []string{"root"}}); err != nil {
+		t.Fatalf("WithSignedSSHKey failed: %s", err)
+	}
+	if !strings.HasPrefix(opts.UserData, "#cloud-config\nwrite_files:\n") {
+		t.Fatalf("UserData is not a valid cloud-config document: %v", opts.UserData)
+	}
+
+	// The content value is generated via encoding/json, which produces a
+	// valid YAML flow scalar; decode it back out the same way to assert on
+	// its value without pulling in a YAML parser.
+	contentPrefix := "    content: "
+	i := strings.Index(opts.UserData, contentPrefix)
+	if i < 0 {
+		t.Fatalf("no content field found in UserData: %v", opts.UserData)
+	}
+	line := opts.UserData[i+len(contentPrefix):]
+	line = line[:strings.IndexByte(line, '\n')]
+	var content string
+	if err := json.Unmarshal([]byte(line), &content); err != nil {
+		t.Fatalf("content field is not valid JSON/YAML: %v: %s", err, line)
+	}
+	if !strings.HasPrefix(content, "ssh-ed25519-cert-v01@openssh.com ") {
+		t.Errorf("unexpected certificate content: %v", content)
+	}
+}
+
+func TestServerCreateOptsWithSignedSSHKeyRejectsExistingUserData(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	ca, _ := newTestSSHCAClient(t, env.Client)
+
+	opts := &ServerCreateOpts{Name: "test", UserData: "#cloud-config\nruncmd:\n  - echo hi\n"}
+	ctx := context.Background()
+	err := opts.WithSignedSSHKey(ctx, ca, &SSHKey{ID: 1}, SSHSignOpts{ValidPrincipals: []string{"root"}})
+	if err == nil {
+		t.Fatal("expected an error when UserData is already set")
+	}
+	if opts.UserData != "#cloud-config\nruncmd:\n  - echo hi\n" {
+		t.Errorf("UserData was modified despite the error: %v", opts.UserData)
+	}
+}
@@ -1,7 +1,6 @@
 package hcloud
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -35,15 +34,10 @@ type SSHKeyClient struct {
 
 // Get retrieves a SSH key.
 func (c *SSHKeyClient) Get(ctx context.Context, id int) (*SSHKey, *Response, error) {
-	req, err := c.client.NewRequest(ctx, "GET", fmt.Sprintf("/ssh_keys/%d", id), nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var body struct {
 		SSHKey schema.SSHKey `json:"ssh_key"`
 	}
-	resp, err := c.client.Do(req, &body)
+	resp, err := c.client.doWithRetry(ctx, "GET", fmt.Sprintf("/ssh_keys/%d", id), nil, &body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -59,15 +53,10 @@ type SSHKeyListOpts struct {
 // List returns a list of SSH keys for a specific page.
 func (c *SSHKeyClient) List(ctx context.Context, opts SSHKeyListOpts) ([]*SSHKey, *Response, error) {
 	path := "/ssh_keys?" + valuesForListOpts(opts.ListOpts).Encode()
-	req, err := c.client.NewRequest(ctx, "GET", path, nil)
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var body struct {
 		SSHKeys []schema.SSHKey `json:"ssh_keys"`
 	}
-	resp, err := c.client.Do(req, &body)
+	resp, err := c.client.doWithRetry(ctx, "GET", path, nil, &body)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -79,6 +68,26 @@ func (c *SSHKeyClient) List(ctx context.Context, opts SSHKeyListOpts) ([]*SSHKey
 	return sshKeys, resp, nil
 }
 
+// EachPage calls fn for each page of SSH keys matching opts, stopping when
+// fn returns false, returns an error, or there are no more pages.
+func (c *SSHKeyClient) EachPage(ctx context.Context, opts SSHKeyListOpts, fn func([]*SSHKey) (bool, error)) error {
+	_, err := c.client.EachPage(ctx, "/ssh_keys", opts.ListOpts, func(page Page) (bool, error) {
+		var body struct {
+			SSHKeys []schema.SSHKey `json:"ssh_keys"`
+		}
+		if err := json.Unmarshal(page.Body, &body); err != nil {
+			return false, err
+		}
+		sshKeys := make([]*SSHKey, 0, len(body.SSHKeys))
+		for _, s := range body.SSHKeys {
+			sshKey := SSHKeyFromSchema(s)
+			sshKeys = append(sshKeys, &sshKey)
+		}
+		return fn(sshKeys)
+	})
+	return err
+}
+
 // All returns all SSH keys.
 func (c *SSHKeyClient) All(ctx context.Context) ([]*SSHKey, error) {
 	allSSHKeys := []*SSHKey{}
@@ -86,14 +95,9 @@ func (c *SSHKeyClient) All(ctx context.Context) ([]*SSHKey, error) {
 	opts := SSHKeyListOpts{}
 	opts.PerPage = 50
 
-	_, err := c.client.all(func(page int) (*Response, error) {
-		opts.Page = page
-		sshKeys, resp, err := c.List(ctx, opts)
-		if err != nil {
-			return resp, err
-		}
+	err := c.EachPage(ctx, opts, func(sshKeys []*SSHKey) (bool, error) {
 		allSSHKeys = append(allSSHKeys, sshKeys...)
-		return resp, nil
+		return true, nil
 	})
 	if err != nil {
 		return nil, err
@@ -137,15 +141,10 @@ func (c *SSHKeyClient) Create(ctx context.Context, opts SSHKeyCreateOpts) (*SSHK
 		return nil, nil, err
 	}
 
-	req, err := c.client.NewRequest(ctx, "POST", "/ssh_keys", bytes.NewReader(reqBodyData))
-	if err != nil {
-		return nil, nil, err
-	}
-
 	var respBody struct {
 		SSHKey schema.SSHKey `json:"ssh_key"`
 	}
-	resp, err := c.client.Do(req, &respBody)
+	resp, err := c.client.doWithRetry(ctx, "POST", "/ssh_keys", reqBodyData, &respBody)
 	if err != nil {
 		return nil, resp, err
 	}
@@ -155,9 +154,5 @@ func (c *SSHKeyClient) Create(ctx context.Context, opts SSHKeyCreateOpts) (*SSHK
 
 // Delete deletes a SSH key.
 func (c *SSHKeyClient) Delete(ctx context.Context, id int) (*Response, error) {
-	req, err := c.client.NewRequest(ctx, "DELETE", fmt.Sprintf("/ssh_keys/%d", id), nil)
-	if err != nil {
-		return nil, err
-	}
-	return c.client.Do(req, nil)
+	return c.client.doWithRetry(ctx, "DELETE", fmt.Sprintf("/ssh_keys/%d", id), nil, nil)
 }
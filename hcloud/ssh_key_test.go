@@ -0,0 +1,97 @@
+package hcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/hetznercloud/hcloud-go/hcloud/schema"
+)
+
+func TestSSHKeyClientEachPage(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	var requestedPages []string
+
+	env.Mux.HandleFunc("/ssh_keys", func(w http.ResponseWriter, r *http.Request) {
+		requestedPages = append(requestedPages, r.URL.Query().Get("page"))
+
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("page") {
+		case "", "1":
+			json.NewEncoder(w).Encode(schema.SSHKeyListResponse{
+				SSHKeys: []schema.SSHKey{{ID: 1}, {ID: 2}},
+				Meta: schema.Meta{
+					Pagination: &schema.MetaPagination{
+						Page:     1,
+						NextPage: 2,
+						LastPage: 2,
+					},
+				},
+			})
+		default:
+			json.NewEncoder(w).Encode(schema.SSHKeyListResponse{
+				SSHKeys: []schema.SSHKey{{ID: 3}},
+				Meta: schema.Meta{
+					Pagination: &schema.MetaPagination{
+						Page:     2,
+						LastPage: 2,
+					},
+				},
+			})
+		}
+	})
+
+	ctx := context.Background()
+	var allIDs []int
+	err := env.Client.SSHKey.EachPage(ctx, SSHKeyListOpts{}, func(sshKeys []*SSHKey) (bool, error) {
+		for _, sshKey := range sshKeys {
+			allIDs = append(allIDs, sshKey.ID)
+		}
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("EachPage failed: %s", err)
+	}
+	if len(requestedPages) != 2 {
+		t.Fatalf("expected 2 pages to be requested; got %d", len(requestedPages))
+	}
+	if len(allIDs) != 3 || allIDs[0] != 1 || allIDs[1] != 2 || allIDs[2] != 3 {
+		t.Errorf("unexpected SSH key IDs: %v", allIDs)
+	}
+}
+
+func TestSSHKeyClientEachPageStopsEarly(t *testing.T) {
+	env := newTestEnv()
+	defer env.Teardown()
+
+	requests := 0
+
+	env.Mux.HandleFunc("/ssh_keys", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(schema.SSHKeyListResponse{
+			SSHKeys: []schema.SSHKey{{ID: 1}},
+			Meta: schema.Meta{
+				Pagination: &schema.MetaPagination{
+					Page:     1,
+					NextPage: 2,
+					LastPage: 2,
+				},
+			},
+		})
+	})
+
+	ctx := context.Background()
+	err := env.Client.SSHKey.EachPage(ctx, SSHKeyListOpts{}, func(sshKeys []*SSHKey) (bool, error) {
+		return false, nil
+	})
+	if err != nil {
+		t.Fatalf("EachPage failed: %s", err)
+	}
+	if requests != 1 {
+		t.Fatalf("expected iteration to stop after the first page; got %d requests", requests)
+	}
+}